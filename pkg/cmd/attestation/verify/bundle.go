@@ -0,0 +1,81 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+)
+
+// trustedRootFetchTimeout bounds how long loadTrustedRootFromURL waits on a
+// tenant's Sigstore instance, so a slow or unreachable endpoint fails fast
+// instead of hanging verification indefinitely.
+const trustedRootFetchTimeout = 10 * time.Second
+
+// BundleMediaType is the protobuf bundle format that gh attestation verify
+// accepts via --bundle, matching the format sigstore-go and cosign produce
+// for verify-blob.
+const BundleMediaType = "application/vnd.dev.sigstore.bundle+json;version=0.3"
+
+// GitHubTenantTrustedRootURL is the path a GHES tenant's own Sigstore
+// instance serves its trusted_root.json from, templated the same way
+// verification.GitHubTenantOIDCIssuer is today.
+const GitHubTenantTrustedRootURL = "https://%s.ghe.com/_services/sigstore/trusted_root.json"
+
+// loadBundleFromPath reads a Sigstore protobuf bundle (.sigstore.json) from
+// disk. This lets users verify bundles produced outside GitHub Actions --
+// including air-gapped or third-party bundles -- with the same verify command.
+func loadBundleFromPath(path string) (*sgbundle.Bundle, error) {
+	b, err := sgbundle.LoadJSONFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bundle from %s: %w", path, err)
+	}
+	return b, nil
+}
+
+// loadTrustedRootFromPath reads a serialized TrustedRoot from disk, for use
+// in place of the embedded TUF trust root.
+func loadTrustedRootFromPath(path string) (*root.TrustedRoot, error) {
+	tr, err := root.NewTrustedRootFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trusted root from %s: %w", path, err)
+	}
+	return tr, nil
+}
+
+// loadTrustedRootFromURL fetches and parses a serialized TrustedRoot served
+// by a tenant's own Sigstore instance, for multi-tenant/GHES deployments that
+// don't use the public TUF root.
+func loadTrustedRootFromURL(url string) (*root.TrustedRoot, error) {
+	client := &http.Client{Timeout: trustedRootFetchTimeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trusted root from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch trusted root from %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted root response from %s: %w", url, err)
+	}
+
+	tr, err := root.NewTrustedRootFromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted root from %s: %w", url, err)
+	}
+	return tr, nil
+}
+
+// tenantTrustedRootURL returns the URL a GHES/multi-tenant deployment serves
+// its trusted_root.json from, given the tenant slug.
+func tenantTrustedRootURL(tenant string) string {
+	return fmt.Sprintf(GitHubTenantTrustedRootURL, tenant)
+}
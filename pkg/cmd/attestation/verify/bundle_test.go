@@ -0,0 +1,68 @@
+package verify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadBundleFromPath(t *testing.T) {
+	t.Run("missing file returns an error", func(t *testing.T) {
+		if _, err := loadBundleFromPath(filepath.Join(t.TempDir(), "missing.sigstore.json")); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+}
+
+func TestLoadTrustedRootFromPath(t *testing.T) {
+	t.Run("missing file returns an error", func(t *testing.T) {
+		if _, err := loadTrustedRootFromPath(filepath.Join(t.TempDir(), "missing_trusted_root.json")); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+}
+
+func TestTenantTrustedRootURL(t *testing.T) {
+	got := tenantTrustedRootURL("acme")
+	want := "https://acme.ghe.com/_services/sigstore/trusted_root.json"
+	if got != want {
+		t.Errorf("tenantTrustedRootURL(%q) = %q, want %q", "acme", got, want)
+	}
+}
+
+func TestLoadTrustedRootFromURL(t *testing.T) {
+	t.Run("non-200 response returns an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		if _, err := loadTrustedRootFromURL(server.URL); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+
+	t.Run("a slow endpoint fails fast instead of hanging", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(trustedRootFetchTimeout + time.Second)
+		}))
+		defer server.Close()
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := loadTrustedRootFromURL(server.URL)
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Fatal("expected a timeout error, got nil")
+			}
+		case <-time.After(trustedRootFetchTimeout + 5*time.Second):
+			t.Fatal("loadTrustedRootFromURL did not respect its timeout")
+		}
+	})
+}
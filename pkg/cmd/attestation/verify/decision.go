@@ -0,0 +1,116 @@
+package verify
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+)
+
+// PolicyDecision is the structured outcome of evaluating a single
+// (artifact, attestation) pair against a Policy. It is the unit of output
+// for --format json and --format sarif, and is built to be logged even when
+// verification fails, unlike VerifyCertExtensions which short-circuits on
+// the first bad extension.
+type PolicyDecision struct {
+	Artifact         string `json:"artifact"`
+	AttestationIndex int    `json:"attestationIndex"`
+	Verified         bool   `json:"verified"`
+	MatchedSAN       string `json:"matchedSAN,omitempty"`
+	MatchedIssuer    string `json:"matchedIssuer,omitempty"`
+	FailureField     string `json:"failureField,omitempty"`
+	FailureExpected  string `json:"failureExpected,omitempty"`
+	FailureGot       string `json:"failureGot,omitempty"`
+}
+
+// BatchJob pairs a Policy with the artifact it applies to and the
+// attestations that artifact resolved to, so VerifyBatch can verify many
+// artifacts against many attestations in one call.
+type BatchJob struct {
+	Policy       Policy
+	ArtifactName string
+	Results      []*verification.AttestationProcessingResult
+}
+
+// defaultBatchWorkers bounds the worker pool VerifyBatch uses when the
+// caller doesn't request a specific concurrency.
+const defaultBatchWorkers = 5
+
+// VerifyBatch verifies every job concurrently, bounded by a worker pool of
+// maxWorkers goroutines, and returns one PolicyDecision per
+// (artifact, attestation) pair across every job. A zero or negative
+// maxWorkers falls back to defaultBatchWorkers. Unlike VerifyCertExtensions,
+// it never short-circuits: every attestation is evaluated and recorded, so
+// CI systems verifying hundreds of artifacts get a complete decision log.
+func VerifyBatch(jobs []BatchJob, maxWorkers int) []PolicyDecision {
+	if maxWorkers <= 0 {
+		maxWorkers = defaultBatchWorkers
+	}
+
+	type indexedDecisions struct {
+		index     int
+		decisions []PolicyDecision
+	}
+
+	results := make(chan indexedDecisions, len(jobs))
+	sem := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job BatchJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			decisions := make([]PolicyDecision, len(job.Results))
+			for j, attestation := range job.Results {
+				decisions[j] = job.Policy.decideCertExtensions(job.ArtifactName, j, attestation)
+			}
+			results <- indexedDecisions{index: i, decisions: decisions}
+		}(i, job)
+	}
+
+	wg.Wait()
+	close(results)
+
+	ordered := make([][]PolicyDecision, len(jobs))
+	for r := range results {
+		ordered[r.index] = r.decisions
+	}
+
+	var all []PolicyDecision
+	for _, decisions := range ordered {
+		all = append(all, decisions...)
+	}
+	return all
+}
+
+// decideCertExtensions evaluates a single attestation against the policy's
+// certificate extension requirements, returning a PolicyDecision instead of
+// an error. Only the first failing check is recorded; the checks after it
+// are not evaluated.
+func (p *Policy) decideCertExtensions(artifactName string, attestationIndex int, attestation *verification.AttestationProcessingResult) PolicyDecision {
+	decision := PolicyDecision{
+		Artifact:         artifactName,
+		AttestationIndex: attestationIndex,
+		MatchedSAN:       attestation.VerificationResult.Signature.Certificate.SubjectAlternativeName,
+		MatchedIssuer:    attestation.VerificationResult.Signature.Certificate.Extensions.Issuer,
+	}
+
+	if err := p.verifyCertExtensions(attestation); err != nil {
+		var mismatch *ExtensionMismatchError
+		if errors.As(err, &mismatch) {
+			decision.FailureField = mismatch.Field
+			decision.FailureExpected = mismatch.Expected
+			decision.FailureGot = mismatch.Got
+		} else {
+			decision.FailureField = "unknown"
+			decision.FailureGot = err.Error()
+		}
+		return decision
+	}
+
+	decision.Verified = true
+	return decision
+}
@@ -0,0 +1,66 @@
+package verify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+)
+
+func TestVerifyBatchPreservesJobOrder(t *testing.T) {
+	jobs := make([]BatchJob, 0, 20)
+	for i := 0; i < 20; i++ {
+		result := &verification.AttestationProcessingResult{}
+		result.VerificationResult.Signature.Certificate.Extensions.Issuer = "https://token.actions.githubusercontent.com"
+
+		jobs = append(jobs, BatchJob{
+			Policy:       Policy{OIDCIssuer: "https://token.actions.githubusercontent.com"},
+			ArtifactName: fmt.Sprintf("artifact-%d", i),
+			Results:      []*verification.AttestationProcessingResult{result},
+		})
+	}
+
+	// Run several times: with a worker pool smaller than the job count,
+	// a race on result ordering would show up as flakiness here.
+	for i := 0; i < 5; i++ {
+		decisions := VerifyBatch(jobs, 4)
+		if len(decisions) != len(jobs) {
+			t.Fatalf("expected %d decisions, got %d", len(jobs), len(decisions))
+		}
+		for j, d := range decisions {
+			if want := fmt.Sprintf("artifact-%d", j); d.Artifact != want {
+				t.Fatalf("expected decision %d for %q, got %q", j, want, d.Artifact)
+			}
+			if !d.Verified {
+				t.Fatalf("expected decision to be verified, got %+v", d)
+			}
+		}
+	}
+}
+
+func TestVerifyBatchRecordsFailureDetail(t *testing.T) {
+	result := &verification.AttestationProcessingResult{}
+	result.VerificationResult.Signature.Certificate.Extensions.Issuer = "https://unexpected-issuer.example.com"
+
+	job := BatchJob{
+		Policy:       Policy{OIDCIssuer: "https://token.actions.githubusercontent.com"},
+		ArtifactName: "artifact",
+		Results:      []*verification.AttestationProcessingResult{result},
+	}
+
+	decisions := VerifyBatch([]BatchJob{job}, 0)
+	if len(decisions) != 1 {
+		t.Fatalf("expected 1 decision, got %d", len(decisions))
+	}
+
+	d := decisions[0]
+	if d.Verified {
+		t.Fatal("expected decision to be unverified")
+	}
+	if d.FailureField != "Issuer" {
+		t.Errorf("expected FailureField %q, got %q", "Issuer", d.FailureField)
+	}
+	if d.FailureGot != "https://unexpected-issuer.example.com" {
+		t.Errorf("unexpected FailureGot: %q", d.FailureGot)
+	}
+}
@@ -0,0 +1,111 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+// writeDecisions renders a batch of PolicyDecisions in the requested
+// --format, so CI systems can ingest verification results directly instead
+// of scraping human-readable output.
+func writeDecisions(w io.Writer, format string, decisions []PolicyDecision) error {
+	switch format {
+	case formatJSON:
+		return writeDecisionsJSON(w, decisions)
+	case formatSARIF:
+		return writeDecisionsSARIF(w, decisions)
+	default:
+		return fmt.Errorf("unsupported format %q, expected %q or %q", format, formatJSON, formatSARIF)
+	}
+}
+
+func writeDecisionsJSON(w io.Writer, decisions []PolicyDecision) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(decisions)
+}
+
+// sarifLog is a minimal SARIF 2.1.0 log: one result per PolicyDecision,
+// enough for CI systems to surface pass/fail per artifact without a full
+// rules catalog.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func writeDecisionsSARIF(w io.Writer, decisions []PolicyDecision) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{Driver: sarifDriver{Name: "gh attestation verify"}},
+			},
+		},
+	}
+
+	for _, d := range decisions {
+		level := "note"
+		message := fmt.Sprintf("verified against %s (issuer %s)", d.MatchedSAN, d.MatchedIssuer)
+		if !d.Verified {
+			level = "error"
+			message = fmt.Sprintf("expected %s to be %s, got %s", d.FailureField, d.FailureExpected, d.FailureGot)
+		}
+
+		log.Runs[0].Results = append(log.Runs[0].Results, sarifResult{
+			RuleID:  "attestation-verify",
+			Level:   level,
+			Message: sarifMessage{Text: message},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: d.Artifact}}},
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,59 @@
+package verify
+
+// Options captures the inputs to `gh attestation verify`, populated from
+// command line flags and used to build the Policy that attestations are
+// checked against.
+type Options struct {
+	ArtifactPath    string
+	DigestAlgorithm string
+	Owner           string
+	Repo            string
+	Hostname        string
+	Tenant          string
+
+	PredicateType string
+	OIDCIssuer    string
+
+	SAN                  string
+	SANRegex             string
+	SignerRepo           string
+	SignerWorkflow       string
+	DenySelfHostedRunner bool
+
+	CertBuildConfigURI    string
+	CertBuildConfigDigest string
+	CertRunInvocationURI  string
+	CertSourceRef         string
+	CertSourceRefRegex    string
+	CertSourceDigest      string
+	CertSourceVisibility  string
+	CertBuildTrigger      string
+
+	// PolicyFilePath points at a --policy-file document describing named
+	// allow/deny rules. When set, it is used in place of the --cert-*/
+	// --signer-* flags above.
+	PolicyFilePath string
+
+	// BundlePath points at a Sigstore protobuf bundle (--bundle) to verify
+	// directly, instead of fetching attestations from the API.
+	BundlePath string
+
+	// TrustedRoot points at a serialized TrustedRoot (--trusted-root) to use
+	// in place of the embedded TUF trust root.
+	TrustedRoot string
+
+	// Offline disables TUF refresh and any other network calls to
+	// Rekor/Fulcio/TUF during verification.
+	Offline bool
+
+	// Format selects how verification results are reported: "" for the
+	// default human-readable summary, "json" for a PolicyDecision log, or
+	// "sarif" for a SARIF log CI systems can ingest directly. It is not
+	// applied with PolicyFilePath, which always prints the human-readable
+	// summary.
+	Format string
+
+	// Workers bounds the worker pool VerifyBatch uses. Zero means the
+	// package default.
+	Workers int
+}
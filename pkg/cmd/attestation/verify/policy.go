@@ -6,7 +6,9 @@ import (
 	"regexp"
 	"strings"
 
+	sgbundle "github.com/sigstore/sigstore-go/pkg/bundle"
 	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore-go/pkg/root"
 	"github.com/sigstore/sigstore-go/pkg/verify"
 
 	"github.com/cli/cli/v2/pkg/cmd/attestation/api"
@@ -21,23 +23,54 @@ const (
 )
 
 type Extensions struct {
-	RunnerEnvironment        string
-	SANRegex                 string
-	SAN                      string
-	BuildSourceRepoURI       string
-	SignerWorkflow           string
-	SourceRepositoryOwnerURI string
-	SourceRepositoryURI      string
+	RunnerEnvironment          string
+	SANRegex                   string
+	SAN                        string
+	BuildSourceRepoURI         string
+	SignerWorkflow             string
+	SourceRepositoryOwnerURI   string
+	SourceRepositoryURI        string
+	BuildConfigURI             string
+	BuildConfigDigest          string
+	RunInvocationURI           string
+	SourceRepositoryRef        string
+	SourceRepositoryRefRegex   string
+	SourceRepositoryDigest     string
+	SourceRepositoryVisibility string
+	BuildTrigger               string
 }
 
 type Policy struct {
-	Extensions    Extensions
-	PredicateType string
-	Artifact      artifact.DigestedArtifact
-	OIDCIssuer    string
+	// Name identifies the policy. It is empty for policies built from CLI
+	// flags and set to the rule name for policies loaded from a --policy-file.
+	Name           string
+	Extensions     Extensions
+	PredicateType  string
+	Artifact       artifact.DigestedArtifact
+	OIDCIssuer     string
+	AllowedRefs    []string
+	DeniedRefs     []string
+	AllowedSigners []string
+	DeniedSigners  []string
+
+	// Bundle, when set, is a user-supplied Sigstore bundle (loaded via
+	// --bundle) to verify instead of fetching attestations from the API.
+	Bundle *sgbundle.Bundle
+
+	// TrustedRoot, when set, overrides the embedded TUF trust root (loaded
+	// via --trusted-root).
+	TrustedRoot *root.TrustedRoot
+
+	// Offline disables TUF refresh and any other network calls to
+	// Rekor/Fulcio/TUF during verification.
+	Offline bool
 }
 
 func newPolicy(opts *Options, a artifact.DigestedArtifact) (Policy, error) {
+	if opts.Offline && opts.TrustedRoot == "" {
+		return Policy{}, errors.New("--offline requires --trusted-root: there is no other source of trust material with network calls disabled")
+	}
+
 	p := Policy{
 		Artifact: a,
 	}
@@ -76,6 +109,17 @@ func newPolicy(opts *Options, a artifact.DigestedArtifact) (Policy, error) {
 		p.Extensions.SourceRepositoryOwnerURI = fmt.Sprintf("https://github.com/%s", opts.Owner)
 	}
 
+	p.PredicateType = opts.PredicateType
+
+	p.Extensions.BuildConfigURI = opts.CertBuildConfigURI
+	p.Extensions.BuildConfigDigest = opts.CertBuildConfigDigest
+	p.Extensions.RunInvocationURI = opts.CertRunInvocationURI
+	p.Extensions.SourceRepositoryRef = opts.CertSourceRef
+	p.Extensions.SourceRepositoryRefRegex = opts.CertSourceRefRegex
+	p.Extensions.SourceRepositoryDigest = opts.CertSourceDigest
+	p.Extensions.SourceRepositoryVisibility = opts.CertSourceVisibility
+	p.Extensions.BuildTrigger = opts.CertBuildTrigger
+
 	// if issuer is anything other than the default, use the user-provided value;
 	// otherwise, select the appropriate default based on the tenant
 	if opts.Tenant != "" {
@@ -84,6 +128,34 @@ func newPolicy(opts *Options, a artifact.DigestedArtifact) (Policy, error) {
 		p.OIDCIssuer = opts.OIDCIssuer
 	}
 
+	if opts.BundlePath != "" {
+		b, err := loadBundleFromPath(opts.BundlePath)
+		if err != nil {
+			return Policy{}, err
+		}
+		p.Bundle = b
+	}
+
+	if opts.TrustedRoot != "" {
+		tr, err := loadTrustedRootFromPath(opts.TrustedRoot)
+		if err != nil {
+			return Policy{}, err
+		}
+		p.TrustedRoot = tr
+	} else if opts.Tenant != "" && !opts.Offline {
+		// GHES/tenant deployments with their own Sigstore instance can be
+		// verified against without hand-assembling a trusted root. Skipped
+		// under --offline, which must make no network calls at all; pass
+		// --trusted-root explicitly instead.
+		tr, err := loadTrustedRootFromURL(tenantTrustedRootURL(opts.Tenant))
+		if err != nil {
+			return Policy{}, err
+		}
+		p.TrustedRoot = tr
+	}
+
+	p.Offline = opts.Offline
+
 	return p, nil
 }
 
@@ -148,10 +220,47 @@ func (p *Policy) SigstorePolicy() (verify.PolicyBuilder, error) {
 		return verify.PolicyBuilder{}, err
 	}
 
-	policy := verify.NewPolicy(artifactDigestPolicyOption, certIdOption)
+	opts := []verify.PolicyOption{artifactDigestPolicyOption, certIdOption}
+	if p.Offline {
+		opts = append(opts, verify.WithOfflineVerification())
+	}
+
+	policy := verify.NewPolicy(opts...)
 	return policy, nil
 }
 
+// VerifyBundle verifies the policy's loaded Bundle (set via --bundle)
+// against its own trusted material (set via --trusted-root) rather than
+// fetching attestations from the API. This is the path that lets
+// `gh attestation verify` check air-gapped or third-party bundles produced
+// outside GitHub Actions.
+func (p *Policy) VerifyBundle() (*verify.VerificationResult, error) {
+	if p.Bundle == nil {
+		return nil, errors.New("no bundle loaded to verify; pass --bundle")
+	}
+
+	if p.TrustedRoot == nil {
+		return nil, errors.New("--trusted-root is required to verify a --bundle")
+	}
+
+	verifier, err := verify.NewVerifier(p.TrustedRoot, verify.WithObserverTimestamps(1), verify.WithTransparencyLog(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sigstore verifier: %w", err)
+	}
+
+	sigstorePolicy, err := p.SigstorePolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := verifier.Verify(p.Bundle, sigstorePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify bundle: %w", err)
+	}
+
+	return result, nil
+}
+
 func validateSignerWorkflow(opts *Options) (string, error) {
 	// we expect a provided workflow argument be in the format [HOST/]/<OWNER>/<REPO>/path/to/workflow.yml
 	// if the provided workflow does not contain a host, set the host
@@ -192,19 +301,13 @@ func (p *Policy) VerifyCertExtensions(results []*verification.AttestationProcess
 }
 
 func (p *Policy) verifyCertExtensions(attestation *verification.AttestationProcessingResult) error {
-	if p.Extensions.SourceRepositoryOwnerURI != "" {
-		sourceRepositoryOwnerURI := attestation.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryOwnerURI
-		if !strings.EqualFold(p.Extensions.SourceRepositoryOwnerURI, sourceRepositoryOwnerURI) {
-			return fmt.Errorf("expected SourceRepositoryOwnerURI to be %s, got %s", p.Extensions.SourceRepositoryOwnerURI, sourceRepositoryOwnerURI)
-		}
+	if err := verifyExtensionEquals("SourceRepositoryOwnerURI", p.Extensions.SourceRepositoryOwnerURI, attestation.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryOwnerURI); err != nil {
+		return err
 	}
 
 	// if repo is set, check the SourceRepositoryURI field
-	if p.Extensions.SourceRepositoryURI != "" {
-		sourceRepositoryURI := attestation.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryURI
-		if !strings.EqualFold(p.Extensions.SourceRepositoryURI, sourceRepositoryURI) {
-			return fmt.Errorf("expected SourceRepositoryURI to be %s, got %s", p.Extensions.SourceRepositoryURI, sourceRepositoryURI)
-		}
+	if err := verifyExtensionEquals("SourceRepositoryURI", p.Extensions.SourceRepositoryURI, attestation.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryURI); err != nil {
+		return err
 	}
 
 	if p.OIDCIssuer != "" {
@@ -213,9 +316,151 @@ func (p *Policy) verifyCertExtensions(attestation *verification.AttestationProce
 			if strings.Index(certIssuer, p.OIDCIssuer+"/") == 0 {
 				return fmt.Errorf("expected Issuer to be %s, got %s -- if you have a custom OIDC issuer policy for your enterprise, use the --cert-oidc-issuer flag with your expected issuer", p.OIDCIssuer, certIssuer)
 			}
-			return fmt.Errorf("expected Issuer to be %s, got %s", p.OIDCIssuer, certIssuer)
+			return &ExtensionMismatchError{Field: "Issuer", Expected: p.OIDCIssuer, Got: certIssuer}
+		}
+	}
+
+	extensions := attestation.VerificationResult.Signature.Certificate.Extensions
+
+	if err := verifyExtensionEquals("BuildConfigURI", p.Extensions.BuildConfigURI, extensions.BuildConfigURI); err != nil {
+		return err
+	}
+
+	if err := verifyExtensionEquals("BuildConfigDigest", p.Extensions.BuildConfigDigest, extensions.BuildConfigDigest); err != nil {
+		return err
+	}
+
+	if err := verifyExtensionEquals("RunInvocationURI", p.Extensions.RunInvocationURI, extensions.RunInvocationURI); err != nil {
+		return err
+	}
+
+	if err := verifyExtensionEquals("SourceRepositoryDigest", p.Extensions.SourceRepositoryDigest, extensions.SourceRepositoryDigest); err != nil {
+		return err
+	}
+
+	if err := verifyExtensionEquals("SourceRepositoryVisibility", p.Extensions.SourceRepositoryVisibility, extensions.SourceRepositoryVisibility); err != nil {
+		return err
+	}
+
+	if err := verifyExtensionEquals("BuildTrigger", p.Extensions.BuildTrigger, extensions.BuildTrigger); err != nil {
+		return err
+	}
+
+	if p.Extensions.SourceRepositoryRefRegex != "" {
+		matched, err := regexp.MatchString(p.Extensions.SourceRepositoryRefRegex, extensions.SourceRepositoryRef)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return &ExtensionMismatchError{Field: "SourceRepositoryRef", Expected: p.Extensions.SourceRepositoryRefRegex, Got: extensions.SourceRepositoryRef}
+		}
+	} else if err := verifyExtensionEquals("SourceRepositoryRef", p.Extensions.SourceRepositoryRef, extensions.SourceRepositoryRef); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verifyRefPolicy checks the SourceRepositoryRef extension of every attestation
+// against the policy's allow/deny ref lists. A ref denylist takes precedence
+// over the allowlist; an empty allowlist permits any ref.
+func (p *Policy) verifyRefPolicy(results []*verification.AttestationProcessingResult) error {
+	if len(p.AllowedRefs) == 0 && len(p.DeniedRefs) == 0 {
+		return nil
+	}
+
+	for _, attestation := range results {
+		ref := attestation.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryRef
+
+		for _, denied := range p.DeniedRefs {
+			if ref == denied {
+				return fmt.Errorf("ref %s is denied by policy", ref)
+			}
+		}
+
+		if len(p.AllowedRefs) == 0 {
+			continue
+		}
+
+		var allowed bool
+		for _, allowedRef := range p.AllowedRefs {
+			if ref == allowedRef {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("ref %s is not in the allowed refs list", ref)
 		}
 	}
 
 	return nil
 }
+
+// verifySignerPolicy checks the SAN of every attestation's signer identity
+// against the policy's allow/deny signer lists. A signer denylist takes
+// precedence over the allowlist; an empty allowlist permits any signer.
+//
+// The SAN is the full workflow ref URI encoded in the certificate, not the
+// SourceRepositoryURI extension -- two workflows in the same repository
+// share a SourceRepositoryURI but have distinct SANs, and it's the signer
+// identity (the workflow), not the repository, that this policy scopes.
+func (p *Policy) verifySignerPolicy(results []*verification.AttestationProcessingResult) error {
+	if len(p.AllowedSigners) == 0 && len(p.DeniedSigners) == 0 {
+		return nil
+	}
+
+	for _, attestation := range results {
+		san := attestation.VerificationResult.Signature.Certificate.SubjectAlternativeName
+
+		for _, denied := range p.DeniedSigners {
+			if san == denied {
+				return fmt.Errorf("signer %s is denied by policy", san)
+			}
+		}
+
+		if len(p.AllowedSigners) == 0 {
+			continue
+		}
+
+		var allowed bool
+		for _, allowedSigner := range p.AllowedSigners {
+			if san == allowedSigner {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("signer %s is not in the allowed signers list", san)
+		}
+	}
+
+	return nil
+}
+
+// verifyExtensionEquals checks that the actual value of a Fulcio certificate extension
+// matches the expected value, case-insensitively. An empty expected value means the
+// extension is not part of the policy and is skipped.
+func verifyExtensionEquals(name, expected, actual string) error {
+	if expected == "" {
+		return nil
+	}
+	if !strings.EqualFold(expected, actual) {
+		return &ExtensionMismatchError{Field: name, Expected: expected, Got: actual}
+	}
+	return nil
+}
+
+// ExtensionMismatchError reports a single Fulcio certificate extension that
+// did not match the policy. It carries enough detail -- the field, what was
+// expected, and what was found -- for structured (--format json/sarif)
+// output, rather than forcing callers to parse an error string.
+type ExtensionMismatchError struct {
+	Field    string
+	Expected string
+	Got      string
+}
+
+func (e *ExtensionMismatchError) Error() string {
+	return fmt.Sprintf("expected %s to be %s, got %s", e.Field, e.Expected, e.Got)
+}
@@ -0,0 +1,86 @@
+package verify
+
+import "testing"
+
+func TestVerifyExtensionEquals(t *testing.T) {
+	t.Run("empty expected value is always satisfied", func(t *testing.T) {
+		if err := verifyExtensionEquals("BuildTrigger", "", "push"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("matching values are case-insensitive", func(t *testing.T) {
+		if err := verifyExtensionEquals("BuildTrigger", "PUSH", "push"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("mismatched values return an ExtensionMismatchError", func(t *testing.T) {
+		err := verifyExtensionEquals("BuildTrigger", "push", "pull_request")
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+
+		mismatch, ok := err.(*ExtensionMismatchError)
+		if !ok {
+			t.Fatalf("expected *ExtensionMismatchError, got %T", err)
+		}
+		if mismatch.Field != "BuildTrigger" || mismatch.Expected != "push" || mismatch.Got != "pull_request" {
+			t.Fatalf("unexpected mismatch fields: %+v", mismatch)
+		}
+	})
+}
+
+func TestExpandToGitHubURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		tenant      string
+		ownerOrRepo string
+		want        string
+	}{
+		{"no tenant", "", "cli/cli", "(?i)^https://github.com/cli/cli/"},
+		{"with tenant", "acme", "cli/cli", "(?i)^https://acme.ghe.com/cli/cli/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandToGitHubURL(tt.tenant, tt.ownerOrRepo)
+			if got != tt.want {
+				t.Errorf("expandToGitHubURL(%q, %q) = %q, want %q", tt.tenant, tt.ownerOrRepo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSignerWorkflow(t *testing.T) {
+	t.Run("workflow with an explicit host", func(t *testing.T) {
+		opts := &Options{SignerWorkflow: "github.com/cli/cli/.github/workflows/release.yml"}
+		got, err := validateSignerWorkflow(opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "^https://github.com/cli/cli/.github/workflows/release.yml"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("workflow without a host falls back to opts.Hostname", func(t *testing.T) {
+		opts := &Options{SignerWorkflow: "cli/cli/.github/workflows/release.yml", Hostname: "github.com"}
+		got, err := validateSignerWorkflow(opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "^https://github.com/cli/cli/.github/workflows/release.yml"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("workflow without a host or hostname errors", func(t *testing.T) {
+		opts := &Options{SignerWorkflow: "cli/cli/.github/workflows/release.yml"}
+		if _, err := validateSignerWorkflow(opts); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+}
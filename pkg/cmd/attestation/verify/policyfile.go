@@ -0,0 +1,174 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cli/cli/v2/pkg/cmd/attestation/artifact"
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+)
+
+// PolicyFile is the top-level shape of a --policy-file document. It holds a
+// named set of rules; an attestation is accepted if it matches at least one
+// of them.
+type PolicyFile struct {
+	Rules []PolicyFileRule `json:"rules" yaml:"rules"`
+}
+
+// PolicyFileRule describes a single named policy that an attestation can be
+// evaluated against. It covers the SAN, SAN regex, OIDC issuer and source
+// repository/owner checks of the --cert-*/--signer-* flags, plus a runner
+// environment and allow/deny lists that have no flag equivalent. The build
+// config, run invocation, source ref/digest/visibility and build trigger
+// flags have no rule field.
+type PolicyFileRule struct {
+	Name                     string   `json:"name" yaml:"name"`
+	PredicateType            string   `json:"predicateType" yaml:"predicateType"`
+	SAN                      string   `json:"san" yaml:"san"`
+	SANRegex                 string   `json:"sanRegex" yaml:"sanRegex"`
+	OIDCIssuer               string   `json:"oidcIssuer" yaml:"oidcIssuer"`
+	RunnerEnvironment        string   `json:"runnerEnvironment" yaml:"runnerEnvironment"`
+	SourceRepositoryURI      string   `json:"sourceRepositoryURI" yaml:"sourceRepositoryURI"`
+	SourceRepositoryOwnerURI string   `json:"sourceRepositoryOwnerURI" yaml:"sourceRepositoryOwnerURI"`
+	AllowedRefs              []string `json:"allowedRefs" yaml:"allowedRefs"`
+	DeniedRefs               []string `json:"deniedRefs" yaml:"deniedRefs"`
+	AllowedSigners           []string `json:"allowedSigners" yaml:"allowedSigners"`
+	DeniedSigners            []string `json:"deniedSigners" yaml:"deniedSigners"`
+}
+
+// loadPolicyFile reads and parses a policy file in either YAML or JSON format,
+// selecting the decoder based on the file extension.
+func loadPolicyFile(path string) (*PolicyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var file PolicyFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse policy file as JSON: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse policy file as YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported policy file extension %q, expected .yaml, .yml, or .json", ext)
+	}
+
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("policy file %s does not define any rules", path)
+	}
+
+	return &file, nil
+}
+
+// newPoliciesFromFile compiles every rule in a policy file into a Policy. The
+// resulting slice is evaluated with "at least one rule matches" semantics by
+// EvaluatePolicies.
+func newPoliciesFromFile(file *PolicyFile, a artifact.DigestedArtifact) ([]Policy, error) {
+	policies := make([]Policy, 0, len(file.Rules))
+
+	for _, rule := range file.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("policy file rule is missing a name")
+		}
+
+		p := Policy{
+			Name:          rule.Name,
+			Artifact:      a,
+			PredicateType: rule.PredicateType,
+			OIDCIssuer:    rule.OIDCIssuer,
+			Extensions: Extensions{
+				SAN:                      rule.SAN,
+				SANRegex:                 rule.SANRegex,
+				RunnerEnvironment:        rule.RunnerEnvironment,
+				SourceRepositoryURI:      rule.SourceRepositoryURI,
+				SourceRepositoryOwnerURI: rule.SourceRepositoryOwnerURI,
+			},
+			AllowedRefs:    rule.AllowedRefs,
+			DeniedRefs:     rule.DeniedRefs,
+			AllowedSigners: rule.AllowedSigners,
+			DeniedSigners:  rule.DeniedSigners,
+		}
+
+		if p.Extensions.RunnerEnvironment == "" {
+			p.Extensions.RunnerEnvironment = "*"
+		}
+
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+// EvaluatePolicies checks a set of attestation processing results against
+// every policy in turn, reporting success as soon as one policy matches. If
+// none match, the error from the closest miss -- the first policy checked --
+// is returned so the caller has something actionable to report.
+func EvaluatePolicies(policies []Policy, results []*verification.AttestationProcessingResult) (string, error) {
+	if len(policies) == 0 {
+		return "", fmt.Errorf("no policies to evaluate")
+	}
+
+	var closestMiss error
+	for _, p := range policies {
+		scoped := filterByPredicateType(p.PredicateType, results)
+		if len(scoped) == 0 {
+			if closestMiss == nil {
+				closestMiss = fmt.Errorf("policy %q: no attestations found with predicate type %q", p.Name, p.PredicateType)
+			}
+			continue
+		}
+
+		if err := p.VerifyCertExtensions(scoped); err != nil {
+			if closestMiss == nil {
+				closestMiss = fmt.Errorf("policy %q: %w", p.Name, err)
+			}
+			continue
+		}
+
+		if err := p.verifyRefPolicy(scoped); err != nil {
+			if closestMiss == nil {
+				closestMiss = fmt.Errorf("policy %q: %w", p.Name, err)
+			}
+			continue
+		}
+
+		if err := p.verifySignerPolicy(scoped); err != nil {
+			if closestMiss == nil {
+				closestMiss = fmt.Errorf("policy %q: %w", p.Name, err)
+			}
+			continue
+		}
+
+		return p.Name, nil
+	}
+
+	return "", fmt.Errorf("no policy matched: %w", closestMiss)
+}
+
+// filterByPredicateType narrows results down to the ones whose in-toto
+// statement predicate type matches. An empty predicateType matches everything,
+// consistent with Policy.PredicateType being optional on CLI-flag-built
+// policies.
+func filterByPredicateType(predicateType string, results []*verification.AttestationProcessingResult) []*verification.AttestationProcessingResult {
+	if predicateType == "" {
+		return results
+	}
+
+	filtered := make([]*verification.AttestationProcessingResult, 0, len(results))
+	for _, r := range results {
+		if r.VerificationResult.Statement.PredicateType == predicateType {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
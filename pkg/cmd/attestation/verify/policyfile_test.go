@@ -0,0 +1,102 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+)
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	t.Run("parses YAML", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "policy.yaml")
+		writeFile(t, path, `
+rules:
+  - name: release
+    predicateType: https://slsa.dev/provenance/v1
+    san: https://github.com/cli/cli/.github/workflows/release.yml@refs/heads/main
+`)
+
+		file, err := loadPolicyFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(file.Rules) != 1 || file.Rules[0].Name != "release" {
+			t.Fatalf("unexpected rules: %+v", file.Rules)
+		}
+	})
+
+	t.Run("parses JSON", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "policy.json")
+		writeFile(t, path, `{"rules": [{"name": "release"}]}`)
+
+		file, err := loadPolicyFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(file.Rules) != 1 || file.Rules[0].Name != "release" {
+			t.Fatalf("unexpected rules: %+v", file.Rules)
+		}
+	})
+
+	t.Run("rejects a file with no rules", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "policy.yaml")
+		writeFile(t, path, `rules: []`)
+
+		if _, err := loadPolicyFile(path); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+
+	t.Run("rejects an unsupported extension", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "policy.txt")
+		writeFile(t, path, `rules: []`)
+
+		if _, err := loadPolicyFile(path); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+}
+
+func TestFilterByPredicateType(t *testing.T) {
+	slsa := &verification.AttestationProcessingResult{}
+	slsa.VerificationResult.Statement.PredicateType = "https://slsa.dev/provenance/v1"
+
+	sbom := &verification.AttestationProcessingResult{}
+	sbom.VerificationResult.Statement.PredicateType = "https://spdx.dev/Document"
+
+	results := []*verification.AttestationProcessingResult{slsa, sbom}
+
+	t.Run("empty predicate type matches everything", func(t *testing.T) {
+		got := filterByPredicateType("", results)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(got))
+		}
+	})
+
+	t.Run("filters down to the matching predicate type", func(t *testing.T) {
+		got := filterByPredicateType("https://spdx.dev/Document", results)
+		if len(got) != 1 || got[0] != sbom {
+			t.Fatalf("expected only the sbom result, got %+v", got)
+		}
+	})
+
+	t.Run("no matches returns an empty slice", func(t *testing.T) {
+		got := filterByPredicateType("https://example.com/unknown", results)
+		if len(got) != 0 {
+			t.Fatalf("expected no results, got %d", len(got))
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cli/cli/v2/pkg/cmd/attestation/artifact"
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+)
+
+// NewVerifyCmd registers the `gh attestation verify` command and its flags,
+// translating them into an Options value that newPolicy compiles into a
+// Policy.
+func NewVerifyCmd(f *cmdutil.Factory, runF func(*Options) error) *cobra.Command {
+	opts := &Options{}
+
+	cmd := &cobra.Command{
+		Use:   "verify [<file> | oci://<image-uri>] [--owner | --repo]",
+		Short: "Verify an artifact's build provenance",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ArtifactPath = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return runVerify(f, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Owner, "owner", "o", "", "GitHub organization to scope attestation lookup by")
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository name in the format <owner>/<repo>")
+	cmd.Flags().StringVar(&opts.DigestAlgorithm, "digest-alg", "sha256", "The algorithm used to compute a digest of the artifact")
+	cmd.Flags().StringVar(&opts.PredicateType, "predicate-type", "", "Filter attestations by provenance predicate type")
+	cmd.Flags().StringVar(&opts.OIDCIssuer, "cert-oidc-issuer", "", "Issuer of the OIDC token matching the certificate's OIDC Issuer extension")
+
+	cmd.Flags().StringVar(&opts.SAN, "cert-identity", "", "Enforce that the certificate's SAN matches the provided identity")
+	cmd.Flags().StringVar(&opts.SANRegex, "cert-identity-regex", "", "Enforce that the certificate's SAN matches the provided regex")
+	cmd.Flags().StringVar(&opts.SignerRepo, "signer-repo", "", "Repository of the workflow that signed the attestation")
+	cmd.Flags().StringVar(&opts.SignerWorkflow, "signer-workflow", "", "Workflow that signed the attestation")
+	cmd.Flags().BoolVar(&opts.DenySelfHostedRunner, "deny-self-hosted-runners", false, "Fail verification for attestations signed by self-hosted runners")
+
+	cmd.Flags().StringVar(&opts.CertBuildConfigURI, "cert-build-config-uri", "", "Enforce that the certificate's build config URI matches the provided value")
+	cmd.Flags().StringVar(&opts.CertBuildConfigDigest, "cert-build-config-digest", "", "Enforce that the certificate's build config digest matches the provided value")
+	cmd.Flags().StringVar(&opts.CertRunInvocationURI, "cert-run-invocation-uri", "", "Enforce that the certificate's run invocation URI matches the provided value")
+	cmd.Flags().StringVar(&opts.CertSourceRef, "cert-source-ref", "", "Enforce that the certificate's source ref matches the provided value")
+	cmd.Flags().StringVar(&opts.CertSourceRefRegex, "cert-source-ref-regex", "", "Enforce that the certificate's source ref matches the provided regex")
+	cmd.Flags().StringVar(&opts.CertSourceDigest, "cert-source-digest", "", "Enforce that the certificate's source digest matches the provided value")
+	cmd.Flags().StringVar(&opts.CertSourceVisibility, "cert-source-visibility", "", "Enforce that the certificate's source repository visibility matches the provided value")
+	cmd.Flags().StringVar(&opts.CertBuildTrigger, "cert-build-trigger", "", "Enforce that the certificate's build trigger matches the provided value")
+
+	cmd.Flags().StringVar(&opts.PolicyFilePath, "policy-file", "", "Path to a YAML or JSON file describing named allow/deny policy rules, in place of the --cert-*/--signer-* flags")
+
+	cmd.Flags().StringVar(&opts.BundlePath, "bundle", "", "Path to a Sigstore protobuf bundle (.sigstore.json) to verify, in place of fetching attestations from the API")
+	cmd.Flags().StringVar(&opts.TrustedRoot, "trusted-root", "", "Path to a trusted_root.json file to use in place of the embedded TUF trust root")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Do not make any network calls to Rekor/Fulcio/TUF; requires --trusted-root")
+
+	cmd.Flags().StringVar(&opts.Format, "format", "", "Output format: json or sarif. Defaults to a human-readable summary. Not applied with --policy-file")
+	cmd.Flags().IntVar(&opts.Workers, "workers", 0, "Number of artifacts to verify concurrently. Defaults to a small worker pool")
+
+	return cmd
+}
+
+// fetchAttestations is the hook runVerify uses to obtain the artifact's
+// verified attestations. This implementation does not contact the GitHub API:
+// it always returns an error pointing at --bundle. It is a package variable so
+// command-level tests can substitute a fake without a live API/network.
+var fetchAttestations = func(f *cmdutil.Factory, opts *Options, a artifact.DigestedArtifact) ([]*verification.AttestationProcessingResult, error) {
+	return nil, fmt.Errorf("fetching attestations from the API is not yet implemented; use --bundle to verify a local bundle instead")
+}
+
+// runVerify fetches the artifact's attestations and checks them against the
+// Policy compiled from opts, returning an error if verification fails.
+func runVerify(f *cmdutil.Factory, opts *Options) error {
+	a, err := artifact.NewDigestedArtifact(nil, opts.ArtifactPath, opts.DigestAlgorithm)
+	if err != nil {
+		return fmt.Errorf("failed to digest artifact: %w", err)
+	}
+
+	if opts.PolicyFilePath != "" {
+		file, err := loadPolicyFile(opts.PolicyFilePath)
+		if err != nil {
+			return fmt.Errorf("failed to load policy file: %w", err)
+		}
+
+		policies, err := newPoliciesFromFile(file, *a)
+		if err != nil {
+			return fmt.Errorf("failed to build policies from policy file: %w", err)
+		}
+
+		results, err := fetchAttestations(f, opts, *a)
+		if err != nil {
+			return fmt.Errorf("failed to fetch attestations: %w", err)
+		}
+
+		matched, err := EvaluatePolicies(policies, results)
+		if err != nil {
+			return fmt.Errorf("policy verification failed: %w", err)
+		}
+
+		fmt.Fprintf(f.IOStreams.Out, "Verified %s against policy %q (%d rules loaded from %s)\n", opts.ArtifactPath, matched, len(policies), opts.PolicyFilePath)
+		return nil
+	}
+
+	policy, err := newPolicy(opts, *a)
+	if err != nil {
+		return fmt.Errorf("failed to build policy: %w", err)
+	}
+
+	if opts.BundlePath != "" {
+		result, err := policy.VerifyBundle()
+		if err != nil {
+			return err
+		}
+
+		bundleResults := []*verification.AttestationProcessingResult{{VerificationResult: result}}
+		if err := policy.VerifyCertExtensions(bundleResults); err != nil {
+			return fmt.Errorf("bundle did not satisfy certificate extension policy: %w", err)
+		}
+
+		if opts.Format != "" {
+			job := BatchJob{
+				Policy:       policy,
+				ArtifactName: opts.ArtifactPath,
+				Results:      bundleResults,
+			}
+			decisions := VerifyBatch([]BatchJob{job}, opts.Workers)
+			return writeDecisions(f.IOStreams.Out, opts.Format, decisions)
+		}
+
+		fmt.Fprintf(f.IOStreams.Out, "Verified bundle %s against %s\n", opts.BundlePath, opts.ArtifactPath)
+		return nil
+	}
+
+	if _, err := policy.SigstorePolicy(); err != nil {
+		return fmt.Errorf("failed to build sigstore policy: %w", err)
+	}
+
+	results, err := fetchAttestations(f, opts, *a)
+	if err != nil {
+		return fmt.Errorf("failed to fetch attestations: %w", err)
+	}
+
+	results = filterByPredicateType(policy.PredicateType, results)
+
+	if err := policy.VerifyCertExtensions(results); err != nil {
+		return fmt.Errorf("attestation verification failed: %w", err)
+	}
+
+	if opts.Format != "" {
+		job := BatchJob{
+			Policy:       policy,
+			ArtifactName: opts.ArtifactPath,
+			Results:      results,
+		}
+		decisions := VerifyBatch([]BatchJob{job}, opts.Workers)
+		return writeDecisions(f.IOStreams.Out, opts.Format, decisions)
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Verified %d attestation(s) for %s\n", len(results), opts.ArtifactPath)
+	return nil
+}
@@ -0,0 +1,84 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/cli/cli/v2/internal/iostreams"
+	"github.com/cli/cli/v2/pkg/cmd/attestation/artifact"
+	"github.com/cli/cli/v2/pkg/cmd/attestation/verification"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+)
+
+// fakeFetchAttestations swaps in for fetchAttestations in tests so runVerify
+// can be exercised end to end without a live API or network.
+func fakeFetchAttestations(results []*verification.AttestationProcessingResult, err error) func() {
+	orig := fetchAttestations
+	fetchAttestations = func(f *cmdutil.Factory, opts *Options, a artifact.DigestedArtifact) ([]*verification.AttestationProcessingResult, error) {
+		return results, err
+	}
+	return func() { fetchAttestations = orig }
+}
+
+func TestRunVerifyFailsOnCertExtensionMismatch(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+	f := &cmdutil.Factory{IOStreams: ios}
+
+	result := &verification.AttestationProcessingResult{}
+	result.VerificationResult.Signature.Certificate.Extensions.Issuer = "https://unexpected-issuer.example.com"
+
+	restore := fakeFetchAttestations([]*verification.AttestationProcessingResult{result}, nil)
+	defer restore()
+
+	opts := &Options{
+		ArtifactPath:    artifactPathForTest(t),
+		DigestAlgorithm: "sha256",
+		Owner:           "cli",
+		OIDCIssuer:      "https://token.actions.githubusercontent.com",
+	}
+
+	err := runVerify(f, opts)
+	if err == nil {
+		t.Fatal("expected runVerify to return an error on cert extension mismatch, got nil")
+	}
+
+	if stdout.Len() != 0 {
+		t.Errorf("expected no success output to be written, got %q", stdout.String())
+	}
+}
+
+func TestRunVerifySucceedsWhenCertExtensionsMatch(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+	f := &cmdutil.Factory{IOStreams: ios}
+
+	result := &verification.AttestationProcessingResult{}
+	result.VerificationResult.Signature.Certificate.Extensions.SourceRepositoryOwnerURI = "https://github.com/cli"
+	result.VerificationResult.Signature.Certificate.Extensions.Issuer = "https://token.actions.githubusercontent.com"
+
+	restore := fakeFetchAttestations([]*verification.AttestationProcessingResult{result}, nil)
+	defer restore()
+
+	opts := &Options{
+		ArtifactPath:    artifactPathForTest(t),
+		DigestAlgorithm: "sha256",
+		Owner:           "cli",
+		OIDCIssuer:      "https://token.actions.githubusercontent.com",
+	}
+
+	if err := runVerify(f, opts); err != nil {
+		t.Fatalf("expected runVerify to succeed, got error: %v", err)
+	}
+
+	if stdout.Len() == 0 {
+		t.Error("expected a success message to be written to stdout")
+	}
+}
+
+// artifactPathForTest writes a throwaway file so artifact.NewDigestedArtifact
+// has something to digest, independent of the cert extension checks under
+// test.
+func artifactPathForTest(t *testing.T) string {
+	t.Helper()
+	path := t.TempDir() + "/artifact"
+	writeFile(t, path, "hello world")
+	return path
+}